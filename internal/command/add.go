@@ -11,7 +11,9 @@ import (
 	"github.com/hashicorp/terraform/internal/command/arguments"
 	"github.com/hashicorp/terraform/internal/command/views"
 	"github.com/hashicorp/terraform/internal/configs"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
 	"github.com/hashicorp/terraform/internal/states"
+	"github.com/hashicorp/terraform/internal/terraform"
 	"github.com/hashicorp/terraform/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -105,37 +107,160 @@ func (c *AddCommand) Run(rawArgs []string) int {
 		return 1
 	}
 
+	// Bulk generation reads a whole set of (state address -> config address)
+	// pairs, either every resource in state or a user-supplied mapping, and
+	// shares the backend/context/schemas loaded above across all of them so
+	// that it scales to large states without re-initializing per resource.
+	if args.FromStateAll || args.FromStateMapFile != "" {
+		state, stateDiags := c.addLoadState(b)
+		diags = diags.Append(stateDiags)
+		if stateDiags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+
+		mappings, mappingDiags := c.addMappings(args, state)
+		diags = diags.Append(mappingDiags)
+		if mappingDiags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+
+		resources := make([]views.AddResource, 0, len(mappings))
+		for _, m := range mappings {
+			r, rDiags := c.addBuildResource(ctx, args, state, m.To, &m.From)
+			diags = diags.Append(rDiags)
+			if rDiags.HasErrors() {
+				continue
+			}
+			resources = append(resources, r)
+		}
+		if diags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+
+		// Bulk results are rendered as a single batch, rather than one
+		// Resource call per mapping, so that -json produces one valid
+		// document instead of several concatenated ones.
+		diags = diags.Append(view.Resources(resources))
+		if diags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+		return 0
+	}
+
+	var fromAddr *addrs.AbsResourceInstance
+	var state *states.State
+	if args.FromResourceAddr != nil {
+		fromAddr = args.FromResourceAddr
+		var stateDiags tfdiags.Diagnostics
+		state, stateDiags = c.addLoadState(b)
+		diags = diags.Append(stateDiags)
+		if stateDiags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+	}
+
+	r, rDiags := c.addBuildResource(ctx, args, state, args.Addr, fromAddr)
+	diags = diags.Append(rDiags)
+	if rDiags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	diags = diags.Append(view.Resource(r))
+	if diags.HasErrors() {
+		c.View.Diagnostics(diags)
+		return 1
+	}
+
+	return 0
+}
+
+// addLoadState returns the current state for the selected workspace,
+// refreshing it first.
+func (c *AddCommand) addLoadState(b backend.Backend) (*states.State, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	env, err := c.Workspace()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Error selecting workspace", err.Error()))
+		return nil, diags
+	}
+	stateMgr, err := b.StateMgr(env)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Error loading state", fmt.Sprintf(errStateLoadingState, err)))
+		return nil, diags
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Error refreshing state", err.Error()))
+		return nil, diags
+	}
+
+	state := stateMgr.State()
+	if state == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"No state",
+			"There is no state found for the current configuration, so add cannot populate values.",
+		))
+		return nil, diags
+	}
+	return state, diags
+}
+
+// addBuildResource looks up the schema and, if fromAddr is set, the state
+// value for a single resource, and assembles the views.AddResource that
+// describes its generated template. It is the unit of work shared by both
+// the single-address path and the bulk -from-state-all/-from-state=@file
+// paths; callers are responsible for handing the result(s) to the view.
+func (c *AddCommand) addBuildResource(ctx *terraform.Context, args *arguments.Add, state *states.State, addr addrs.AbsResourceInstance, fromAddr *addrs.AbsResourceInstance) (views.AddResource, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
 	// load the configuration to verify that the resource address doesn't
 	// already exist in the config.
 	var module *configs.Module
-	if args.Addr.Module.IsRoot() {
+	if addr.Module.IsRoot() {
 		module = ctx.Config().Module
 	} else {
 		// This is weird, but users can potentially specify non-existant module names
-		cfg := ctx.Config().Root.Descendent(args.Addr.Module.Module())
+		cfg := ctx.Config().Root.Descendent(addr.Module.Module())
 		if cfg != nil {
 			module = cfg.Module
 		}
 	}
 
-	if module == nil {
-		// It's fine if the module doesn't actually exist; we don't need to check if the resource exists.
-	} else {
-		if rs, ok := module.ManagedResources[args.Addr.ContainingResource().Config().String()]; ok {
+	if module != nil {
+		existing := module.ManagedResources
+		if addr.Resource.Resource.Mode == addrs.DataResourceMode {
+			existing = module.DataResources
+		}
+		if rs, ok := existing[addr.ContainingResource().Config().String()]; ok {
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  "Resource already in configuration",
-				Detail:   fmt.Sprintf("The resource %s is already in this configuration at %s. Resource names must be unique per type in each module.", args.Addr, rs.DeclRange),
+				Detail:   fmt.Sprintf("The resource %s is already in this configuration at %s. Resource names must be unique per type in each module.", addr, rs.DeclRange),
 				Subject:  &rs.DeclRange,
 			})
-			c.View.Diagnostics(diags)
-			return 1
+			return views.AddResource{}, diags
 		}
 	}
 
+	if args.WithProvisioner != "" && addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid use of -with-provisioner",
+			fmt.Sprintf("The resource %s is a data source, and data sources cannot have provisioners.", addr),
+		))
+		return views.AddResource{}, diags
+	}
+
 	// Get the schemas from the context
 	schemas := ctx.Schemas()
-	rs := args.Addr.Resource.Resource
+	rs := addr.Resource.Resource
 
 	// If the provider was set on the command line, find the local name for that provider.
 	var providerLocalName string
@@ -159,8 +284,7 @@ func (c *AddCommand) Run(rawArgs []string) int {
 			"Missing schema for provider",
 			fmt.Sprintf("No schema found for provider %s. Please verify that this provider exists in the configuration.", absProvider.String()),
 		))
-		c.View.Diagnostics(diags)
-		return 1
+		return views.AddResource{}, diags
 	}
 
 	schema, schemaVersion := schemas.ResourceTypeConfig(absProvider, rs.Mode, rs.Type)
@@ -170,67 +294,51 @@ func (c *AddCommand) Run(rawArgs []string) int {
 			"Missing resource schema from provider",
 			fmt.Sprintf("No resource schema found for %s.", rs.Type),
 		))
-		c.View.Diagnostics(diags)
-		return 1
+		return views.AddResource{}, diags
 	}
 
-	var rio *states.ResourceInstanceObject
-	if args.FromResourceAddr != nil {
-		// Get the state
-		env, err := c.Workspace()
-		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Error selecting workspace: %s", err))
-			return 1
-		}
-		stateMgr, err := b.StateMgr(env)
-		if err != nil {
-			c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
-			return 1
-		}
-		if err := stateMgr.RefreshState(); err != nil {
-			c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
-			return 1
-		}
-
-		state := stateMgr.State()
-		if state == nil {
+	var provisionerSchema *configschema.Block
+	if args.WithProvisioner != "" {
+		provisionerSchema = schemas.Provisioners[args.WithProvisioner]
+		if provisionerSchema == nil {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
-				"No state",
-				"There is no state found for the current configuration, so add cannot populate values.",
+				"Missing provisioner schema",
+				fmt.Sprintf("No schema found for provisioner %q. Please verify that this provisioner is registered.", args.WithProvisioner),
 			))
-			c.View.Diagnostics(diags)
-			return 1
+			return views.AddResource{}, diags
 		}
-		ri := state.ResourceInstance(*args.FromResourceAddr)
+	}
+
+	var rio *states.ResourceInstanceObject
+	if fromAddr != nil {
+		ri := state.ResourceInstance(*fromAddr)
 		if ri.Current == nil {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
 				"No state for resource",
-				fmt.Sprintf("There is no state found for the resource %s, so add cannot populate values.", rs.String()),
+				fmt.Sprintf("There is no state found for the resource %s, so add cannot populate values.", fromAddr),
 			))
-			c.View.Diagnostics(diags)
-			return 1
+			return views.AddResource{}, diags
 		}
+		var err error
 		rio, err = ri.Current.Decode(schema.ImpliedType())
 		if err != nil {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Error decoding state",
-				fmt.Sprintf("Error decoding state for resource %s: %s", rs.String(), err.Error()),
+				fmt.Sprintf("Error decoding state for resource %s: %s", fromAddr, err.Error()),
 			))
-			c.View.Diagnostics(diags)
-			return 1
+			return views.AddResource{}, diags
 		}
 
 		if ri.Current.SchemaVersion != schemaVersion {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Schema version mismatch",
-				fmt.Sprintf("schema version %d for %s in state does not match version %d from the provider", ri.Current.SchemaVersion, rs.String(), schemaVersion),
+				fmt.Sprintf("schema version %d for %s in state does not match version %d from the provider", ri.Current.SchemaVersion, fromAddr, schemaVersion),
 			))
-			c.View.Diagnostics(diags)
-			return 1
+			return views.AddResource{}, diags
 		}
 	}
 
@@ -241,21 +349,24 @@ func (c *AddCommand) Run(rawArgs []string) int {
 		val = cty.NilVal
 	}
 
-	diags = diags.Append(view.Resource(args.Addr, schema, providerLocalName, val))
-	if diags.HasErrors() {
-		c.View.Diagnostics(diags)
-		return 1
-	}
-
-	return 0
+	return views.AddResource{
+		Addr:              addr,
+		Schema:            schema,
+		SchemaVersion:     schemaVersion,
+		Provider:          absProvider,
+		ProviderLocalName: providerLocalName,
+		StateVal:          val,
+		ProvisionerName:   args.WithProvisioner,
+		ProvisionerSchema: provisionerSchema,
+	}, diags
 }
 
 func (c *AddCommand) Help() string {
 	helpText := `
 Usage: terraform [global options] add [options] ADDRESS
 
-  Generates a blank resource template. With no additional flags,
-  the template will be displayed in the terminal. 
+  Generates a blank resource, or data source, template. With no additional
+  flags, the template will be displayed in the terminal.
 
 Options:
 
@@ -263,6 +374,19 @@ Options:
                         The resource must be the same type as the target address,
 						and exist in state.
 
+-from-state=@file		Generate configuration for every address listed in the given
+						HCL or JSON file, which maps state addresses to the config
+						addresses their templates should use. In the HCL form,
+						addresses are given as a "mappings" object whose keys and
+						values are quoted state and config addresses, since
+						addresses are not valid bare HCL identifiers. Mutually
+						exclusive with a single ADDRESS argument.
+
+-from-state-all			Generate a template for every resource currently in state,
+						instead of just ADDRESS. May be combined with -from-state=@file,
+						in which case addresses not listed in the file are mapped to
+						themselves.
+
 -out=string 			Write the template to a file. If the file already
 						exists, the template will be added to the end of
 						the file.
@@ -271,6 +395,25 @@ Options:
 
 -provider=provider		Override the configured provider for the resource.
 
+-with-import			When used with -from-state=ADDRESS, also emit an
+						import block that imports the resource at ADDRESS,
+						closing the loop between "add" and the declarative
+						import workflow.
+
+-import-id=string		Override the import ID used by -with-import. If not
+						set, the ID is derived from the resource's "id"
+						attribute in state.
+
+-json				Emit a machine-readable JSON document describing the
+						resource and its attributes, instead of an HCL
+						template. If combined with -with-import, the document
+						includes an "import" object with the same "to"/"id"
+						pair as the HCL import block.
+
+-with-provisioner=NAME		Append a provisioner block skeleton for the named
+						provisioner inside the generated resource block.
+						Not valid for data sources.
+
 `
 	return strings.TrimSpace(helpText)
 }
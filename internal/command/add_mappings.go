@@ -0,0 +1,168 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/command/arguments"
+	"github.com/hashicorp/terraform/internal/states"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// addMappings resolves the full set of (state address -> config address)
+// pairs that a bulk "add" invocation should generate configuration for.
+//
+// When args.FromStateMapFile is set, the pairs come from that file. When
+// args.FromStateAll is set, every resource instance currently in state
+// (managed or data) is mapped to itself. The two may be combined, in which
+// case addresses from the mapping file take priority and FromStateAll fills
+// in everything else.
+//
+// The result is sorted by From address so that bulk generation produces the
+// same order on every run, regardless of map iteration order.
+func (c *AddCommand) addMappings(args *arguments.Add, state *states.State) ([]arguments.AddressMapping, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	var mappings []arguments.AddressMapping
+	mapped := make(map[string]bool)
+
+	if args.FromStateMapFile != "" {
+		fileMappings, fileDiags := loadAddressMappingFile(args.FromStateMapFile)
+		diags = diags.Append(fileDiags)
+		if fileDiags.HasErrors() {
+			return nil, diags
+		}
+		for _, m := range fileMappings {
+			mapped[m.From.String()] = true
+			mappings = append(mappings, m)
+		}
+	}
+
+	if args.FromStateAll {
+		for _, ms := range state.Modules {
+			for _, rs := range ms.Resources {
+				for key := range rs.Instances {
+					addr := rs.Addr.Instance(key)
+					if mapped[addr.String()] {
+						continue
+					}
+					mappings = append(mappings, arguments.AddressMapping{From: addr, To: addr})
+				}
+			}
+		}
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].From.String() < mappings[j].From.String()
+	})
+
+	return mappings, diags
+}
+
+// loadAddressMappingFile reads a mapping of state address to config address
+// from an HCL or JSON file, keyed by filename extension.
+//
+// In the HCL form, addresses are written as quoted strings rather than bare
+// attribute names, since addresses such as "aws_instance.foo[0]" or
+// "module.x.data.aws_ami.y" are not valid HCL identifiers:
+//
+//	mappings = {
+//	  "aws_instance.foo" = "aws_instance.bar"
+//	}
+func loadAddressMappingFile(path string) ([]arguments.AddressMapping, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read address mapping file",
+			fmt.Sprintf("Could not read %q: %s", path, err),
+		))
+		return nil, diags
+	}
+
+	raw := make(map[string]string)
+	if strings.HasSuffix(path, ".json") {
+		if err := json.Unmarshal(src, &raw); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid address mapping file",
+				fmt.Sprintf("Could not parse %q as JSON: %s", path, err),
+			))
+			return nil, diags
+		}
+	} else {
+		f, hclDiags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+		diags = diags.Append(hclDiags)
+		if hclDiags.HasErrors() {
+			return nil, diags
+		}
+		attrs, attrDiags := f.Body.JustAttributes()
+		diags = diags.Append(attrDiags)
+		if attrDiags.HasErrors() {
+			return nil, diags
+		}
+		attr, ok := attrs["mappings"]
+		if !ok {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid address mapping file",
+				fmt.Sprintf("%q must set a \"mappings\" attribute, an object whose keys and values are quoted state and config addresses.", path),
+			))
+			return nil, diags
+		}
+		val, valDiags := attr.Expr.Value(nil)
+		diags = diags.Append(valDiags)
+		if valDiags.HasErrors() {
+			return nil, diags
+		}
+		if !val.CanIterateElements() {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid address mapping file",
+				fmt.Sprintf("The \"mappings\" attribute in %q must be an object mapping quoted state addresses to quoted config addresses.", path),
+			))
+			return nil, diags
+		}
+		for it := val.ElementIterator(); it.Next(); {
+			keyVal, elemVal := it.Element()
+			if keyVal.Type() != cty.String || elemVal.Type() != cty.String {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Invalid address mapping file",
+					fmt.Sprintf("The \"mappings\" attribute in %q must map strings to strings.", path),
+				))
+				continue
+			}
+			raw[keyVal.AsString()] = elemVal.AsString()
+		}
+	}
+
+	fromStrs := make([]string, 0, len(raw))
+	for fromStr := range raw {
+		fromStrs = append(fromStrs, fromStr)
+	}
+	sort.Strings(fromStrs)
+
+	mappings := make([]arguments.AddressMapping, 0, len(raw))
+	for _, fromStr := range fromStrs {
+		from, fromDiags := addrs.ParseAbsResourceInstanceStr(fromStr)
+		diags = diags.Append(fromDiags)
+		to, toDiags := addrs.ParseAbsResourceInstanceStr(raw[fromStr])
+		diags = diags.Append(toDiags)
+		if fromDiags.HasErrors() || toDiags.HasErrors() {
+			continue
+		}
+		mappings = append(mappings, arguments.AddressMapping{From: from, To: to})
+	}
+
+	return mappings, diags
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAddressMappingFile_hcl(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mappings.tfvars")
+	// Keys are written out of order deliberately: the returned mappings
+	// must be sorted by From address regardless of source or map order.
+	src := `
+mappings = {
+  "module.x.data.aws_ami.bar" = "data.aws_ami.bar"
+  "aws_instance.zzz"          = "aws_instance.zzz"
+  "aws_instance.foo[0]"       = "aws_instance.foo"
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write test fixture: %s", err)
+	}
+
+	mappings, diags := loadAddressMappingFile(path)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	gotFrom := make([]string, len(mappings))
+	gotTo := make(map[string]string, len(mappings))
+	for i, m := range mappings {
+		gotFrom[i] = m.From.String()
+		gotTo[m.From.String()] = m.To.String()
+	}
+
+	wantFrom := []string{
+		"aws_instance.foo[0]",
+		"aws_instance.zzz",
+		"module.x.data.aws_ami.bar",
+	}
+	wantTo := map[string]string{
+		"aws_instance.foo[0]":       "aws_instance.foo",
+		"aws_instance.zzz":          "aws_instance.zzz",
+		"module.x.data.aws_ami.bar": "data.aws_ami.bar",
+	}
+
+	if len(gotFrom) != len(wantFrom) {
+		t.Fatalf("got %d mappings, want %d", len(gotFrom), len(wantFrom))
+	}
+	for i := range wantFrom {
+		if gotFrom[i] != wantFrom[i] {
+			t.Errorf("mapping %d: got From %q, want %q (mappings must be sorted by From address)", i, gotFrom[i], wantFrom[i])
+		}
+	}
+	for from, to := range wantTo {
+		if gotTo[from] != to {
+			t.Errorf("mapping for %s: got %q, want %q", from, gotTo[from], to)
+		}
+	}
+}
+
+func TestLoadAddressMappingFile_hclRequiresMappingsAttribute(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mappings.tfvars")
+	src := `
+aws_instance.foo = "aws_instance.bar"
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write test fixture: %s", err)
+	}
+
+	_, diags := loadAddressMappingFile(path)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for a mapping file using bare addresses as attribute names")
+	}
+}
+
+func TestLoadAddressMappingFile_json(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mappings.json")
+	src := `{
+  "aws_instance.foo[0]": "aws_instance.foo"
+}`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("failed to write test fixture: %s", err)
+	}
+
+	mappings, diags := loadAddressMappingFile(path)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if got, want := len(mappings), 1; got != want {
+		t.Fatalf("got %d mappings, want %d", got, want)
+	}
+	if got, want := mappings[0].From.String(), "aws_instance.foo[0]"; got != want {
+		t.Errorf("got From %q, want %q", got, want)
+	}
+	if got, want := mappings[0].To.String(), "aws_instance.foo"; got != want {
+		t.Errorf("got To %q, want %q", got, want)
+	}
+}
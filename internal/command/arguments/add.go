@@ -0,0 +1,178 @@
+package arguments
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// ViewType indicates which view layer should render the output of the
+// "add" command.
+type ViewType rune
+
+const (
+	ViewHuman ViewType = 'h'
+	ViewJSON  ViewType = 'j'
+)
+
+// AddressMapping associates the address of a resource already tracked in
+// state with the address that the generated configuration block should be
+// written under. The two addresses are usually the same, but a mapping file
+// can be used to rename resources as their configuration is generated.
+type AddressMapping struct {
+	From addrs.AbsResourceInstance
+	To   addrs.AbsResourceInstance
+}
+
+// Add represents the command-line arguments for the "terraform add" command.
+type Add struct {
+	// Addr is the address of the resource to generate configuration for.
+	// It is ignored when FromStateAll or FromStateMapFile is set, since in
+	// those cases the command instead operates over every mapped address.
+	Addr addrs.AbsResourceInstance
+
+	// FromResourceAddr, if set, is the address of a single resource in
+	// state whose values should be used to populate the generated
+	// configuration.
+	FromResourceAddr *addrs.AbsResourceInstance
+
+	// FromStateAll, when set, tells the command to generate a configuration
+	// block for every resource currently tracked in state, rather than just
+	// the one named by Addr.
+	FromStateAll bool
+
+	// FromStateMapFile, when set, is the path to an HCL or JSON file mapping
+	// state addresses to the configuration addresses that the generated
+	// blocks should use. It is mutually exclusive with Addr.
+	FromStateMapFile string
+
+	// ImportID, if set, overrides the import ID that would otherwise be
+	// derived from the state value when WithImport is set.
+	ImportID string
+
+	// Optional, if set, includes optional attributes in the generated
+	// template.
+	Optional bool
+
+	// Out, if set, is the file that the generated template(s) should be
+	// appended to. If empty, the template is written to stdout.
+	Out string
+
+	// Provider, if set, overrides the provider that would otherwise be
+	// implied by the resource type.
+	Provider addrs.Provider
+
+	// State captures the state-related flags shared with other commands.
+	State *State
+
+	// ViewType determines how the output of this command should be
+	// rendered.
+	ViewType ViewType
+
+	// WithImport, when set alongside FromResourceAddr, additionally emits a
+	// Terraform 1.5-style import block next to the generated resource
+	// block.
+	WithImport bool
+
+	// WithProvisioner, if set, is the name of a provisioner whose schema
+	// should be used to append a provisioner block skeleton inside the
+	// generated resource block. It only applies to managed resources.
+	WithProvisioner string
+}
+
+// ParseAdd parses the command-line arguments for the "add" command.
+func ParseAdd(args []string) (*Add, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	a := &Add{
+		State: &State{},
+	}
+
+	var fromState string
+	var provider string
+	var jsonOutput bool
+
+	cmdFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	cmdFlags.StringVar(&a.State.StatePath, "state", "", "path to state file")
+	cmdFlags.StringVar(&fromState, "from-state", "", "populate values from an existing resource in state, or @file to read a mapping of addresses")
+	cmdFlags.BoolVar(&a.FromStateAll, "from-state-all", false, "generate configuration for every resource in state")
+	cmdFlags.BoolVar(&a.Optional, "optional", false, "include optional attributes")
+	cmdFlags.StringVar(&a.Out, "out", "", "write the template to a file")
+	cmdFlags.StringVar(&provider, "provider", "", "override the provider for the resource")
+	cmdFlags.BoolVar(&a.WithImport, "with-import", false, "also emit an import block for the resource named by -from-state")
+	cmdFlags.StringVar(&a.ImportID, "import-id", "", "override the import ID used by -with-import")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "emit a machine-readable JSON document instead of HCL")
+	cmdFlags.StringVar(&a.WithProvisioner, "with-provisioner", "", "append a provisioner block skeleton for the named provisioner")
+
+	if err := cmdFlags.Parse(args); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to parse command-line flags",
+			err.Error(),
+		))
+		return a, diags
+	}
+
+	a.ViewType = ViewHuman
+	if jsonOutput {
+		a.ViewType = ViewJSON
+	}
+
+	if provider != "" {
+		p, pDiags := addrs.ParseProviderSourceString(provider)
+		diags = diags.Append(pDiags)
+		a.Provider = p
+	}
+
+	switch {
+	case strings.HasPrefix(fromState, "@"):
+		a.FromStateMapFile = strings.TrimPrefix(fromState, "@")
+	case fromState != "":
+		addr, addrDiags := addrs.ParseAbsResourceInstanceStr(fromState)
+		diags = diags.Append(addrDiags)
+		if !addrDiags.HasErrors() {
+			a.FromResourceAddr = &addr
+		}
+	}
+
+	if a.WithImport && a.FromResourceAddr == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid command line arguments",
+			"The -with-import flag requires -from-state=ADDRESS, since an import block can only be generated for a single resource already tracked in state.",
+		))
+	}
+
+	if a.FromResourceAddr != nil && (a.FromStateAll || a.FromStateMapFile != "") {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid command line arguments",
+			"The -from-state=ADDRESS form is mutually exclusive with -from-state-all and -from-state=@file, which generate configuration for every mapped resource rather than a single address.",
+		))
+	}
+
+	posArgs := cmdFlags.Args()
+	switch {
+	case a.FromStateAll || a.FromStateMapFile != "":
+		if len(posArgs) != 0 {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Too many command line arguments",
+				"The -from-state-all and -from-state=@file forms generate configuration for every mapped resource, so no ADDRESS argument should be given.",
+			))
+		}
+	case len(posArgs) != 1:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid command line arguments",
+			"The add command expects a single ADDRESS argument, unless -from-state-all or -from-state=@file is used.",
+		))
+	default:
+		addr, addrDiags := addrs.ParseAbsResourceInstanceStr(posArgs[0])
+		diags = diags.Append(addrDiags)
+		a.Addr = addr
+	}
+
+	return a, diags
+}
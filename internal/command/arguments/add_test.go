@@ -0,0 +1,36 @@
+package arguments
+
+import "testing"
+
+func TestParseAdd_mutuallyExclusiveFromState(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wantErr bool
+	}{
+		"from-state address alone": {
+			args:    []string{"-from-state=aws_instance.foo", "aws_instance.bar"},
+			wantErr: false,
+		},
+		"from-state-all alone": {
+			args:    []string{"-from-state-all"},
+			wantErr: false,
+		},
+		"from-state address with from-state-all": {
+			args:    []string{"-from-state=aws_instance.foo", "-from-state-all"},
+			wantErr: true,
+		},
+		"from-state address with from-state map file": {
+			args:    []string{"-from-state=aws_instance.foo", "-from-state=@mappings.tfvars"},
+			wantErr: false, // the second -from-state flag occurrence wins, leaving only the map file set
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := ParseAdd(test.args)
+			if got := diags.HasErrors(); got != test.wantErr {
+				t.Errorf("got HasErrors() = %v, want %v (diags: %s)", got, test.wantErr, diags.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package arguments
+
+// State captures the subset of state-related flags shared by several
+// commands that need to locate or override the state used for an operation.
+type State struct {
+	// StatePath overrides the path to the state file used for the
+	// operation, in the same way as the global -state flag.
+	StatePath string
+}
@@ -0,0 +1,152 @@
+package views
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/command/arguments"
+	"github.com/hashicorp/terraform/internal/configs/configgen"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// AddResource bundles the inputs needed to render a single generated
+// configuration block.
+type AddResource struct {
+	Addr              addrs.AbsResourceInstance
+	Schema            *configschema.Block
+	SchemaVersion     uint64
+	Provider          addrs.Provider
+	ProviderLocalName string
+
+	// StateVal is cty.NilVal when there is no state to populate values from.
+	StateVal cty.Value
+
+	// ProvisionerName and ProvisionerSchema are set together when
+	// -with-provisioner was given, and describe the provisioner block
+	// skeleton that should be nested inside the generated resource block.
+	ProvisionerName   string
+	ProvisionerSchema *configschema.Block
+}
+
+// Add is the view interface used by AddCommand to render generated
+// configuration templates.
+type Add interface {
+	// Resource renders a configuration template for r.
+	Resource(r AddResource) tfdiags.Diagnostics
+
+	// Resources renders the configuration templates for rs as a single
+	// batch. Bulk "add" invocations (-from-state-all, -from-state=@file)
+	// use this instead of calling Resource once per entry, so that a view
+	// like AddJSON can produce one valid document covering every resource
+	// rather than several documents concatenated on the same stream.
+	Resources(rs []AddResource) tfdiags.Diagnostics
+
+	Diagnostics(diags tfdiags.Diagnostics)
+}
+
+// NewAdd returns an implementation of Add appropriate for the given view
+// type.
+func NewAdd(vt arguments.ViewType, view *View, args *arguments.Add) Add {
+	switch vt {
+	case arguments.ViewJSON:
+		return &AddJSON{view: view, args: args}
+	default:
+		return &AddHuman{view: view, args: args}
+	}
+}
+
+// AddHuman is the default, HCL-emitting implementation of Add.
+type AddHuman struct {
+	view *View
+	args *arguments.Add
+}
+
+var _ Add = (*AddHuman)(nil)
+
+func (v *AddHuman) Resource(r AddResource) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	f := hclwrite.NewEmptyFile()
+
+	if v.args.WithImport {
+		importID, importDiags := importIDFor(r.Addr, r.Schema, r.StateVal, v.args.ImportID)
+		diags = diags.Append(importDiags)
+		if importDiags.HasErrors() {
+			return diags
+		}
+		generateImportBlock(f.Body(), r.Addr, importID)
+	}
+
+	generated, genDiags := configgen.GenerateResource(r.Addr, r.Schema, r.ProviderLocalName, r.StateVal, configgen.Options{IncludeOptional: v.args.Optional})
+	diags = diags.Append(genDiags)
+	if genDiags.HasErrors() {
+		return diags
+	}
+	block := f.Body().AppendBlock(generated)
+
+	if r.ProvisionerSchema != nil {
+		generateProvisionerBlock(block.Body(), r.ProvisionerName, r.ProvisionerSchema, v.args.Optional)
+	}
+
+	diags = diags.Append(writeOutput(v.args.Out, f.Bytes()))
+	return diags
+}
+
+// Resources renders each of rs as its own HCL template, one after another.
+// Unlike AddJSON, there's no batch format to produce here: concatenating
+// independently rendered HCL blocks is itself valid HCL.
+func (v *AddHuman) Resources(rs []AddResource) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, r := range rs {
+		diags = diags.Append(v.Resource(r))
+	}
+	return diags
+}
+
+func (v *AddHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+// writeOutput appends data to out, or to stdout if out is empty. It is
+// shared by every Add view implementation, so that -out behaves
+// identically regardless of output format.
+func writeOutput(out string, data []byte) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if out == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to write to stdout",
+				err.Error(),
+			))
+		}
+		return diags
+	}
+
+	f, err := os.OpenFile(out, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to open output file",
+			fmt.Sprintf("Could not open %q for writing: %s", out, err),
+		))
+		return diags
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to write output file",
+			fmt.Sprintf("Could not write to %q: %s", out, err),
+		))
+	}
+
+	return diags
+}
@@ -0,0 +1,195 @@
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform/internal/command/arguments"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// addJSONFormatVersion is the version of the JSON document produced by
+// AddJSON. This should be bumped whenever a change is made to the document
+// format that isn't backward-compatible.
+const addJSONFormatVersion = "1.0"
+
+// addResourceJSON is the top-level document emitted by AddJSON.Resource.
+type addResourceJSON struct {
+	FormatVersion string                      `json:"format_version"`
+	Address       string                      `json:"address"`
+	Provider      string                      `json:"provider_name"`
+	SchemaVersion uint64                      `json:"schema_version"`
+	Attributes    map[string]addAttributeJSON `json:"attributes"`
+	Provisioner   *addProvisionerJSON         `json:"provisioner,omitempty"`
+	Import        *addImportJSON              `json:"import,omitempty"`
+}
+
+// addImportJSON describes the import requested via -with-import, mirroring
+// the "to"/"id" pair written into a Terraform 1.5-style import block by the
+// human-readable view.
+type addImportJSON struct {
+	To string `json:"to"`
+	ID string `json:"id"`
+}
+
+// addProvisionerJSON describes the provisioner requested via
+// -with-provisioner.
+type addProvisionerJSON struct {
+	Name       string                      `json:"name"`
+	Attributes map[string]addAttributeJSON `json:"attributes"`
+}
+
+// addAttributeJSON describes a single attribute of the generated resource,
+// including which parts of the schema mark it required/optional/computed
+// and, when available, the value derived from state.
+type addAttributeJSON struct {
+	Required  bool            `json:"required,omitempty"`
+	Optional  bool            `json:"optional,omitempty"`
+	Computed  bool            `json:"computed,omitempty"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+	Value     json.RawMessage `json:"value,omitempty"`
+}
+
+// AddJSON is the -json implementation of Add, producing a machine-readable
+// document rather than HCL text.
+type AddJSON struct {
+	view *View
+	args *arguments.Add
+}
+
+var _ Add = (*AddJSON)(nil)
+
+func (v *AddJSON) Resource(r AddResource) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	doc, docDiags := v.buildDoc(r)
+	diags = diags.Append(docDiags)
+	if docDiags.HasErrors() {
+		return diags
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to render JSON",
+			fmt.Sprintf("Could not marshal the generated configuration for %s: %s", r.Addr, err),
+		))
+		return diags
+	}
+	data = append(data, '\n')
+
+	diags = diags.Append(writeOutput(v.args.Out, data))
+	return diags
+}
+
+// Resources renders rs as a single JSON array, so that bulk "add"
+// invocations produce one valid document instead of one per resource
+// concatenated on the same stream.
+func (v *AddJSON) Resources(rs []AddResource) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	docs := make([]addResourceJSON, 0, len(rs))
+	for _, r := range rs {
+		doc, docDiags := v.buildDoc(r)
+		diags = diags.Append(docDiags)
+		if docDiags.HasErrors() {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	if diags.HasErrors() {
+		return diags
+	}
+
+	data, err := json.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to render JSON",
+			fmt.Sprintf("Could not marshal the generated configuration: %s", err),
+		))
+		return diags
+	}
+	data = append(data, '\n')
+
+	diags = diags.Append(writeOutput(v.args.Out, data))
+	return diags
+}
+
+func (v *AddJSON) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+// buildDoc assembles the JSON document for a single resource, shared by
+// Resource and Resources.
+func (v *AddJSON) buildDoc(r AddResource) (addResourceJSON, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	doc := addResourceJSON{
+		FormatVersion: addJSONFormatVersion,
+		Address:       r.Addr.String(),
+		Provider:      r.Provider.String(),
+		SchemaVersion: r.SchemaVersion,
+		Attributes:    attributesToJSON(r.Schema, r.StateVal),
+	}
+
+	if v.args.WithImport {
+		importID, importDiags := importIDFor(r.Addr, r.Schema, r.StateVal, v.args.ImportID)
+		diags = diags.Append(importDiags)
+		if importDiags.HasErrors() {
+			return addResourceJSON{}, diags
+		}
+		doc.Import = &addImportJSON{To: r.Addr.String(), ID: importID}
+	}
+
+	if r.ProvisionerSchema != nil {
+		doc.Provisioner = &addProvisionerJSON{
+			Name:       r.ProvisionerName,
+			Attributes: attributesToJSON(r.ProvisionerSchema, cty.NilVal),
+		}
+	}
+
+	return doc, diags
+}
+
+// attributesToJSON walks schema's top-level attributes, recording their
+// required/optional/computed/sensitive classification and, when val
+// supplies one, their state-derived value. Sensitive values are omitted
+// rather than redacted, since this document is meant to be consumed by
+// tooling that shouldn't need to special-case a redaction marker.
+func attributesToJSON(schema *configschema.Block, val cty.Value) map[string]addAttributeJSON {
+	out := make(map[string]addAttributeJSON, len(schema.Attributes))
+	names := make([]string, 0, len(schema.Attributes))
+	for name := range schema.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attrS := schema.Attributes[name]
+		entry := addAttributeJSON{
+			Required:  attrS.Required,
+			Optional:  attrS.Optional,
+			Computed:  attrS.Computed,
+			Sensitive: attrS.Sensitive,
+		}
+
+		if !attrS.Sensitive && val != cty.NilVal && !val.IsNull() && val.Type().HasAttribute(name) {
+			attrVal := val.GetAttr(name)
+			if !attrVal.IsNull() {
+				if raw, err := ctyjson.Marshal(attrVal, attrVal.Type()); err == nil {
+					entry.Value = raw
+				}
+			}
+		}
+
+		out[name] = entry
+	}
+	return out
+}
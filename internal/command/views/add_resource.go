@@ -0,0 +1,70 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/configs/configgen"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// generateProvisionerBlock appends a "provisioner" block skeleton for the
+// named provisioner to body, populated from its schema. Provisioners have
+// no state of their own, so the block is always filled with placeholder
+// values.
+func generateProvisionerBlock(body *hclwrite.Body, name string, schema *configschema.Block, includeOptional bool) *hclwrite.Block {
+	block := body.AppendNewBlock("provisioner", []string{name})
+	configgen.FillBody(block.Body(), schema, cty.NilVal, configgen.Options{IncludeOptional: includeOptional})
+	return block
+}
+
+// generateImportBlock appends a Terraform 1.5-style import block pairing
+// addr with importID.
+func generateImportBlock(body *hclwrite.Body, addr addrs.AbsResourceInstance, importID string) *hclwrite.Block {
+	block := body.AppendNewBlock("import", nil)
+	traversal, _ := hclsyntax.ParseTraversalAbs([]byte(addr.String()), "", hcl.InitialPos)
+	block.Body().SetAttributeTraversal("to", traversal)
+	block.Body().SetAttributeValue("id", cty.StringVal(importID))
+	return block
+}
+
+// importIDFor determines the import ID to use for addr's import block: the
+// user-supplied override if given, otherwise the resource instance's "id"
+// attribute, which is the primary identifier exposed by most provider
+// schemas.
+func importIDFor(addr addrs.AbsResourceInstance, schema *configschema.Block, stateVal cty.Value, override string) (string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if override != "" {
+		return override, diags
+	}
+
+	if stateVal == cty.NilVal || stateVal.IsNull() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Cannot determine import ID",
+			fmt.Sprintf("The resource %s has no state value to derive an import ID from. Use -import-id to specify one explicitly.", addr),
+		))
+		return "", diags
+	}
+
+	if _, ok := schema.Attributes["id"]; ok && stateVal.Type().HasAttribute("id") {
+		idVal := stateVal.GetAttr("id")
+		if !idVal.IsNull() && idVal.Type() == cty.String {
+			return idVal.AsString(), diags
+		}
+	}
+
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Cannot determine import ID",
+		fmt.Sprintf("The provider schema for %s does not expose a usable \"id\" attribute. Use -import-id to specify the import ID explicitly.", addr),
+	))
+	return "", diags
+}
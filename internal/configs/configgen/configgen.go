@@ -0,0 +1,111 @@
+// Package configgen generates HCL configuration blocks from a provider
+// schema and, optionally, a cty.Value of existing state. It backs the
+// "terraform add" command. No plan-time config-generation path (such as
+// "terraform plan -generate-config-out") calls into it.
+package configgen
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// Options controls which parts of a schema GenerateResource includes in the
+// generated block.
+type Options struct {
+	// IncludeOptional, when set, includes optional attributes in addition
+	// to required ones. When unset, only required attributes are included.
+	IncludeOptional bool
+}
+
+// GenerateResource builds a "resource" or "data" block (depending on addr's
+// resource mode) for addr, using schema to decide which attributes and
+// nested blocks to include. When val is not cty.NilVal, its attributes are
+// used to populate the generated values; otherwise attributes are left as
+// null placeholders.
+//
+// providerLocalName, if non-empty, is written as the block's "provider"
+// meta-argument, for the case where the resource's provider doesn't match
+// the one that would otherwise be implied by its type.
+func GenerateResource(addr addrs.AbsResourceInstance, schema *configschema.Block, providerLocalName string, val cty.Value, opts Options) (*hclwrite.Block, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	f := hclwrite.NewEmptyFile()
+	rs := addr.Resource.Resource
+	blockType := "resource"
+	if rs.Mode == addrs.DataResourceMode {
+		blockType = "data"
+	}
+	block := f.Body().AppendNewBlock(blockType, []string{rs.Type, rs.Name})
+
+	if providerLocalName != "" {
+		traversal, travDiags := hclsyntax.ParseTraversalAbs([]byte(providerLocalName), "", hcl.InitialPos)
+		diags = diags.Append(travDiags)
+		if !travDiags.HasErrors() {
+			block.Body().SetAttributeTraversal("provider", traversal)
+		}
+	}
+
+	FillBody(block.Body(), schema, val, opts)
+
+	return block, diags
+}
+
+// FillBody writes the attributes and nested blocks described by schema into
+// body, redacting any attribute the provider has marked as sensitive. It is
+// exported so that other scaffolding, such as provisioner block generation,
+// can reuse the same attribute-filling behavior as GenerateResource.
+func FillBody(body *hclwrite.Body, schema *configschema.Block, val cty.Value, opts Options) {
+	names := make([]string, 0, len(schema.Attributes))
+	for name := range schema.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attrS := schema.Attributes[name]
+		if attrS.Computed && !attrS.Optional {
+			// Computed-only attributes are never written by the user.
+			continue
+		}
+		if !attrS.Required && !opts.IncludeOptional {
+			continue
+		}
+
+		attrVal := cty.NilVal
+		if val != cty.NilVal && !val.IsNull() && val.Type().HasAttribute(name) {
+			attrVal = val.GetAttr(name)
+		}
+
+		if attrS.Sensitive {
+			body.SetAttributeValue(name, cty.StringVal("(sensitive value)"))
+			continue
+		}
+
+		switch {
+		case attrVal != cty.NilVal && !attrVal.IsNull():
+			body.SetAttributeValue(name, attrVal)
+		default:
+			body.SetAttributeValue(name, cty.NullVal(attrS.Type))
+		}
+	}
+
+	blockNames := make([]string, 0, len(schema.BlockTypes))
+	for name := range schema.BlockTypes {
+		blockNames = append(blockNames, name)
+	}
+	sort.Strings(blockNames)
+
+	for _, name := range blockNames {
+		blockS := schema.BlockTypes[name]
+		nested := body.AppendNewBlock(name, nil)
+		FillBody(nested.Body(), &blockS.Block, cty.NilVal, opts)
+	}
+}
@@ -0,0 +1,145 @@
+package configgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+)
+
+func TestFillBody(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"required_attr": {Type: cty.String, Required: true},
+			"optional_attr": {Type: cty.String, Optional: true},
+			"computed_attr": {Type: cty.String, Computed: true},
+			"sensitive_attr": {
+				Type:      cty.String,
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"nested": {
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"nested_attr": {Type: cty.String, Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		opts Options
+		want []string
+		dont []string
+	}{
+		"required only": {
+			opts: Options{IncludeOptional: false},
+			want: []string{"required_attr", "sensitive_attr", "nested {", "nested_attr"},
+			dont: []string{"optional_attr", "computed_attr"},
+		},
+		"include optional": {
+			opts: Options{IncludeOptional: true},
+			want: []string{"required_attr", "optional_attr", "sensitive_attr"},
+			dont: []string{"computed_attr"},
+		},
+		"sensitive values are redacted": {
+			opts: Options{IncludeOptional: false},
+			want: []string{`"(sensitive value)"`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := hclwrite.NewEmptyFile()
+			FillBody(f.Body(), schema, cty.NilVal, test.opts)
+			src := string(f.Bytes())
+
+			for _, want := range test.want {
+				if !strings.Contains(src, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, src)
+				}
+			}
+			for _, dont := range test.dont {
+				if strings.Contains(src, dont) {
+					t.Errorf("expected output not to contain %q, got:\n%s", dont, src)
+				}
+			}
+		})
+	}
+}
+
+func TestFillBody_fromState(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"name": {Type: cty.String, Required: true},
+		},
+	}
+	val := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("example"),
+	})
+
+	f := hclwrite.NewEmptyFile()
+	FillBody(f.Body(), schema, val, Options{})
+	src := string(f.Bytes())
+
+	if !strings.Contains(src, `"example"`) {
+		t.Errorf("expected output to contain state-derived value %q, got:\n%s", "example", src)
+	}
+}
+
+func TestGenerateResource(t *testing.T) {
+	addr, diags := addrs.ParseAbsResourceInstanceStr("aws_instance.foo")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors parsing address: %s", diags.Err())
+	}
+
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id": {Type: cty.String, Required: true},
+		},
+	}
+
+	block, diags := GenerateResource(addr, schema, "", cty.NilVal, Options{})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	f := hclwrite.NewEmptyFile()
+	f.Body().AppendBlock(block)
+	src := string(f.Bytes())
+
+	for _, want := range []string{`resource "aws_instance" "foo"`, "id"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerateResource_providerLocalName(t *testing.T) {
+	addr, diags := addrs.ParseAbsResourceInstanceStr("aws_instance.foo")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors parsing address: %s", diags.Err())
+	}
+
+	schema := &configschema.Block{}
+
+	block, diags := GenerateResource(addr, schema, "aws.west", cty.NilVal, Options{})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	f := hclwrite.NewEmptyFile()
+	f.Body().AppendBlock(block)
+	src := string(f.Bytes())
+
+	if !strings.Contains(src, "provider = aws.west") {
+		t.Errorf("expected output to set the provider meta-argument, got:\n%s", src)
+	}
+}